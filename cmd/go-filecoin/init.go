@@ -2,6 +2,8 @@ package commands
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,8 +11,11 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
 	blockstore "github.com/ipfs/go-ipfs-blockstore"
 	cmdkit "github.com/ipfs/go-ipfs-cmdkit"
 	cmds "github.com/ipfs/go-ipfs-cmds"
@@ -27,11 +32,52 @@ import (
 	"github.com/filecoin-project/go-filecoin/internal/pkg/genesis"
 	drandapi "github.com/filecoin-project/go-filecoin/internal/pkg/protocol/drand"
 	"github.com/filecoin-project/go-filecoin/internal/pkg/repo"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/vm/actor/builtin/miner"
+	remotewallet "github.com/filecoin-project/go-filecoin/internal/pkg/wallet/remote"
 	gengen "github.com/filecoin-project/go-filecoin/tools/gengen/util"
 )
 
 var logInit = logging.Logger("commands/init")
 
+// CreateWorkerKey, when set alongside WithMiner, generates a fresh worker key
+// and registers it on the miner actor instead of signing with the owner key.
+const CreateWorkerKey = "create-worker-key"
+
+// WorkerKeyFile imports an existing worker key and registers it on the miner
+// actor, as an alternative to CreateWorkerKey.
+const WorkerKeyFile = "worker"
+
+// PresealedSectorCount, when set along with WithMiner and
+// OptionPresealedSectorDir, fake-seals this many sectors so their power can
+// be baked into a custom genesis block.
+const PresealedSectorCount = "presealed-sector-count"
+
+// PresealedSectorSize is the size in bytes of each sector generated by
+// PresealedSectorCount.
+const PresealedSectorSize = "presealed-sector-size"
+
+// defaultPresealedSectorSize is used when PresealedSectorSize is not set; it
+// matches the small sector size used elsewhere for devnets and tests.
+const defaultPresealedSectorSize = uint64(1024 * 1024)
+
+// WalletRemoteURL, when set, installs a JSON-RPC-backed wallet.Backend that
+// forwards signing to an external signer daemon instead of importing keys
+// into the local keystore.
+const WalletRemoteURL = "wallet-remote-url"
+
+// WalletRemoteTokenFile is the path of a file containing the bearer token
+// used to authenticate with WalletRemoteURL, so the credential never
+// appears on the CLI.
+const WalletRemoteTokenFile = "wallet-remote-token-file"
+
+// GenesisCid, when set, must match the root CID of the genesis CAR loaded
+// via GenesisFile; init fails closed if it does not.
+const GenesisCid = "genesis-cid"
+
+// genesisPartialSuffix marks an in-progress HTTP genesis download on disk so
+// a later init can resume it with a Range request instead of starting over.
+const genesisPartialSuffix = ".part"
+
 var initCmd = &cmds.Command{
 	Helptext: cmdkit.HelpText{
 		Tagline: "Initialize a filecoin repo",
@@ -41,12 +87,19 @@ var initCmd = &cmds.Command{
 		cmdkit.StringOption(PeerKeyFile, "path of file containing key to use for new node's libp2p identity"),
 		cmdkit.StringOption(WalletKeyFile, "path of file containing keys to import into the wallet on initialization"),
 		cmdkit.StringOption(WithMiner, "when set, creates a custom genesis block  a pre generated miner account, requires running the daemon using dev mode (--dev)"),
+		cmdkit.BoolOption(CreateWorkerKey, "when set along with --with-miner, generates a new worker key and registers it on the miner actor separately from the owner key"),
+		cmdkit.StringOption(WorkerKeyFile, "path of file containing an existing worker key to import and register on the miner actor, as an alternative to --create-worker-key"),
 		cmdkit.StringOption(OptionSectorDir, "path of directory into which staged and sealed sectors will be written"),
 		cmdkit.StringOption(MinerActorAddress, "when set, sets the daemons's miner actor address to the provided address"),
 		cmdkit.UintOption(AutoSealIntervalSeconds, "when set to a number > 0, configures the daemon to check for and seal any staged sectors on an interval.").WithDefault(uint(120)),
 		cmdkit.StringOption(Network, "when set, populates config with network specific parameters"),
 		cmdkit.StringOption(OptionPresealedSectorDir, "when set to the path of a directory, imports pre-sealed sector data from that directory"),
+		cmdkit.UintOption(PresealedSectorCount, "when set along with --with-miner and --presealed-sector-dir, fake-seals this many sectors into that directory and writes a pre-seal manifest for them"),
+		cmdkit.UintOption(PresealedSectorSize, "size in bytes of each sector generated by --presealed-sector-count").WithDefault(uint(defaultPresealedSectorSize)),
 		cmdkit.StringOption(OptionDrandConfigAddr, "configure drand with given address, uses secure contact protocol and no override.  If you need different settings use daemon drand command"),
+		cmdkit.StringOption(WalletRemoteURL, "when set, signs with a remote wallet backend at this URL instead of importing keys into the local keystore"),
+		cmdkit.StringOption(WalletRemoteTokenFile, "path of file containing the bearer token to authenticate with --wallet-remote-url"),
+		cmdkit.StringOption(GenesisCid, "when set, the root CID of the genesis file loaded via --genesis-file must match this value"),
 	},
 	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
 		repoDir, _ := req.Options[OptionRepoDir].(string)
@@ -69,17 +122,34 @@ var initCmd = &cmds.Command{
 		defer func() { _ = rep.Close() }()
 
 		genesisFileSource, _ := req.Options[GenesisFile].(string)
-		gif, err := loadGenesis(req.Context, rep, genesisFileSource)
+		presealDir, _ := req.Options[OptionPresealedSectorDir].(string)
+		genesisCidStr, _ := req.Options[GenesisCid].(string)
+		gif, err := loadGenesis(req.Context, rep, genesisFileSource, presealDir, repoDir, genesisCidStr)
 		if err != nil {
 			return err
 		}
 
 		peerKeyFile, _ := req.Options[PeerKeyFile].(string)
 		walletKeyFile, _ := req.Options[WalletKeyFile].(string)
-		initopts, err := getNodeInitOpts(peerKeyFile, walletKeyFile)
+		workerKeyFile, _ := req.Options[WorkerKeyFile].(string)
+		createWorkerKey, _ := req.Options[CreateWorkerKey].(bool)
+		walletRemoteURL, _ := req.Options[WalletRemoteURL].(string)
+		walletRemoteTokenFile, _ := req.Options[WalletRemoteTokenFile].(string)
+		initopts, err := getNodeInitOpts(peerKeyFile, walletKeyFile, workerKeyFile, createWorkerKey, walletRemoteURL, walletRemoteTokenFile)
+		if err != nil {
+			return err
+		}
+
+		presealOpt, err := presealOptIfRequested(req.Options)
 		if err != nil {
 			return err
 		}
+		if presealOpt != nil {
+			// Appended after the worker-key opts above so it runs once Init
+			// has a worker address and peer ID to put in the manifest,
+			// rather than fake-sealing before that material exists.
+			initopts = append(initopts, presealOpt)
+		}
 
 		cfg := rep.Config()
 		if err := setConfigFromOptions(cfg, req.Options); err != nil {
@@ -102,10 +172,31 @@ var initCmd = &cmds.Command{
 			return err
 		}
 
+		if err := registerMinerWorkerAddress(rep); err != nil {
+			logInit.Errorf("Error registering miner worker address %s", err)
+			return err
+		}
+
 		return nil
 	},
 }
 
+// registerMinerWorkerAddress links cfg.Mining.WorkerAddress to
+// cfg.Mining.MinerAddress on-chain as the miner actor's worker, once
+// node.Init has finished running every opt. Doing this here rather than
+// inside the worker-key opts themselves means it doesn't matter whether
+// MinerAddress was set (by setConfigFromOptions, above) before or after
+// those opts ran: by the time Run reaches this point both addresses, if
+// set at all, are already settled in the repo config. It is a no-op if
+// either address was never configured.
+func registerMinerWorkerAddress(rep repo.Repo) error {
+	cfg := rep.Config()
+	if cfg.Mining.WorkerAddress == address.Undef || cfg.Mining.MinerAddress == address.Undef {
+		return nil
+	}
+	return miner.SetWorkerAddress(rep, cfg.Mining.MinerAddress, cfg.Mining.WorkerAddress)
+}
+
 func setConfigFromOptions(cfg *config.Config, options cmdkit.OptMap) error {
 	var err error
 	if dir, ok := options[OptionSectorDir].(string); ok {
@@ -128,6 +219,29 @@ func setConfigFromOptions(cfg *config.Config, options cmdkit.OptMap) error {
 		}
 	}
 
+	if _, ok := options[CreateWorkerKey]; ok {
+		if cfg.Mining.MinerAddress == address.Undef {
+			return fmt.Errorf("if --%s is provided, --%s or --%s must also be provided", CreateWorkerKey, WithMiner, MinerActorAddress)
+		}
+	}
+
+	if wk, ok := options[WorkerKeyFile].(string); ok && wk != "" {
+		if cfg.Mining.MinerAddress == address.Undef {
+			return fmt.Errorf("if --%s is provided, --%s or --%s must also be provided", WorkerKeyFile, WithMiner, MinerActorAddress)
+		}
+	}
+
+	// cfg.Mining.WorkerAddress is not known until node.Init has generated or
+	// imported the worker key, so it is written back into the repo config
+	// from there rather than here (mirroring how a generated MinerAddress is
+	// recorded once gengen has produced it).
+
+	if url, ok := options[WalletRemoteURL].(string); ok && url != "" {
+		cfg.Wallet.RemoteBackend = &config.RemoteWalletConfig{
+			URL: url,
+		}
+	}
+
 	if dir, ok := options[OptionPresealedSectorDir].(string); ok {
 		if cfg.Mining.MinerAddress == address.Undef {
 			return fmt.Errorf("if --%s is provided, --%s or --%s must also be provided", OptionPresealedSectorDir, MinerActorAddress, WithMiner)
@@ -180,18 +294,59 @@ func setDrandConfig(repo repo.Repo, options cmdkit.OptMap) error {
 	return d.Configure([]string{drandAddrStr}, true, false)
 }
 
-func loadGenesis(ctx context.Context, rep repo.Repo, sourceName string) (genesis.InitFunc, error) {
+// presealOptIfRequested returns a node.InitOpt that fake-seals
+// PresealedSectorCount sectors for WithMiner into OptionPresealedSectorDir,
+// or nil if PresealedSectorCount is not set. The opt is run by node.Init
+// rather than invoked directly here; initCmd appends it after the opts from
+// getNodeInitOpts, so it executes after the worker-key opts have given Init
+// a worker address and peer ID to record in the manifest alongside the
+// sector data.
+func presealOptIfRequested(options cmdkit.OptMap) (node.InitOpt, error) {
+	count, ok := options[PresealedSectorCount].(uint)
+	if !ok || count == 0 {
+		return nil, nil
+	}
+
+	minerStr, _ := options[WithMiner].(string)
+	sectorDir, _ := options[OptionPresealedSectorDir].(string)
+	if minerStr == "" || sectorDir == "" {
+		return nil, fmt.Errorf("--%s requires --%s and --%s", PresealedSectorCount, WithMiner, OptionPresealedSectorDir)
+	}
+
+	minerAddr, err := address.NewFromString(minerStr)
+	if err != nil {
+		return nil, err
+	}
+
+	size := defaultPresealedSectorSize
+	if s, ok := options[PresealedSectorSize].(uint); ok && s > 0 {
+		size = uint64(s)
+	}
+
+	return node.PresealOpt(minerAddr, sectorDir, size, uint64(count)), nil
+}
+
+func loadGenesis(ctx context.Context, rep repo.Repo, sourceName string, presealDir string, repoDir string, genesisCidStr string) (genesis.InitFunc, error) {
 	if sourceName == "" {
-		return gengen.MakeGenesisFunc(), nil
+		return gengen.MakeGenesisFunc(presealDir), nil
+	}
+
+	var expectedCid cid.Cid
+	if genesisCidStr != "" {
+		var err error
+		expectedCid, err = cid.Decode(genesisCidStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s: %s", GenesisCid, err)
+		}
 	}
 
-	source, err := openGenesisSource(sourceName)
+	source, err := openGenesisSource(sourceName, repoDir)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = source.Close() }()
 
-	genesisBlk, err := extractGenesisBlock(source, rep)
+	genesisBlk, err := extractGenesisBlock(source, rep, expectedCid)
 	if err != nil {
 		return nil, err
 	}
@@ -204,8 +359,34 @@ func loadGenesis(ctx context.Context, rep repo.Repo, sourceName string) (genesis
 
 }
 
-func getNodeInitOpts(peerKeyFile string, walletKeyFile string) ([]node.InitOpt, error) {
+func getNodeInitOpts(peerKeyFile string, walletKeyFile string, workerKeyFile string, createWorkerKey bool, walletRemoteURL string, walletRemoteTokenFile string) ([]node.InitOpt, error) {
+	if workerKeyFile != "" && createWorkerKey {
+		return nil, fmt.Errorf("only one of --%s or --%s may be provided", CreateWorkerKey, WorkerKeyFile)
+	}
+
+	if walletRemoteURL != "" && walletKeyFile != "" {
+		return nil, fmt.Errorf("only one of --%s or --%s may be provided", WalletRemoteURL, WalletKeyFile)
+	}
+
 	var initOpts []node.InitOpt
+
+	if walletRemoteURL != "" {
+		if walletRemoteTokenFile == "" {
+			return nil, fmt.Errorf("--%s requires --%s", WalletRemoteURL, WalletRemoteTokenFile)
+		}
+
+		backend, err := remotewallet.NewBackend(walletRemoteURL, walletRemoteTokenFile)
+		if err != nil {
+			return nil, err
+		}
+
+		// The remote backend enumerates its own keys via WalletList, so no
+		// local wallet import options are needed or accepted alongside it.
+		// The peer identity and worker key options below are independent of
+		// where signing happens, so they still apply.
+		initOpts = append(initOpts, node.WalletBackendOpt(backend))
+	}
+
 	if peerKeyFile != "" {
 		data, err := ioutil.ReadFile(peerKeyFile)
 		if err != nil {
@@ -238,23 +419,45 @@ func getNodeInitOpts(peerKeyFile string, walletKeyFile string) ([]node.InitOpt,
 		}
 	}
 
+	if workerKeyFile != "" {
+		f, err := os.Open(workerKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		var wir *WalletSerializeResult
+		if err := json.NewDecoder(f).Decode(&wir); err != nil {
+			return nil, err
+		}
+
+		if len(wir.KeyInfo) != 1 {
+			return nil, fmt.Errorf("--%s file must contain exactly one key", WorkerKeyFile)
+		}
+
+		initOpts = append(initOpts, node.WorkerKeyOpt(wir.KeyInfo[0]))
+	} else if createWorkerKey {
+		initOpts = append(initOpts, node.GenerateWorkerKeyOpt())
+	}
+
 	return initOpts, nil
 }
 
-func openGenesisSource(sourceName string) (io.ReadCloser, error) {
+func openGenesisSource(sourceName string, repoDir string) (io.ReadCloser, error) {
 	sourceURL, err := url.Parse(sourceName)
 	if err != nil {
 		return nil, fmt.Errorf("invalid filepath or URL for genesis file: %s", sourceURL)
 	}
 	var source io.ReadCloser
 	if sourceURL.Scheme == "http" || sourceURL.Scheme == "https" {
-		// NOTE: This code is temporary. It allows downloading a genesis block via HTTP(S) to be able to join a
-		// recently deployed staging devnet.
-		response, err := http.Get(sourceName)
+		// This allows downloading a genesis block via HTTP(S) to be able to join a
+		// recently deployed staging devnet. The download resumes from an on-disk
+		// partial file under the repo via a Range request, and is checked against
+		// a detached sha256 sum before being handed to the CAR loader.
+		partialPath := filepath.Join(repoDir, genesisDownloadName(sourceName)+genesisPartialSuffix)
+		source, err = downloadGenesis(sourceName, partialPath)
 		if err != nil {
 			return nil, err
 		}
-		source = response.Body
 	} else if sourceURL.Scheme != "" {
 		return nil, fmt.Errorf("unsupported protocol for genesis file: %s", sourceURL.Scheme)
 	} else {
@@ -267,13 +470,135 @@ func openGenesisSource(sourceName string) (io.ReadCloser, error) {
 	return source, nil
 }
 
-func extractGenesisBlock(source io.ReadCloser, rep repo.Repo) (*block.Block, error) {
+// genesisDownloadName derives a stable, filesystem-safe name for sourceName's
+// partial download file so repeated `init` invocations against the same URL
+// resume the same file instead of colliding or starting fresh.
+func genesisDownloadName(sourceName string) string {
+	sum := sha256.Sum256([]byte(sourceName))
+	return "genesis-" + hex.EncodeToString(sum[:8])
+}
+
+// downloadGenesis fetches sourceName into partialPath, resuming via an HTTP
+// Range request if partialPath already holds a prior attempt, then verifies
+// the completed download against the detached sha256 sum published at
+// "<sourceName>.sha256" before returning it for reading.
+func downloadGenesis(sourceName string, partialPath string) (io.ReadCloser, error) {
+	var offset int64
+	if fi, err := os.Stat(partialPath); err == nil {
+		offset = fi.Size()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, sourceName, nil)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		flags |= os.O_APPEND
+	case http.StatusOK:
+		// The server ignored our Range request (or there was nothing to
+		// resume); restart the download from scratch.
+		offset = 0
+		flags |= os.O_TRUNC
+	default:
+		return nil, fmt.Errorf("downloading genesis file: unexpected status %s", resp.Status)
+	}
+
+	f, err := os.OpenFile(partialPath, flags, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("downloading genesis file: %s", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := verifyGenesisChecksum(sourceName, partialPath); err != nil {
+		// The bytes on disk don't match sourceName's published checksum, so
+		// they must not be reused as a resume point: removing partialPath
+		// forces the next attempt to restart the download from scratch
+		// instead of resuming from (and perpetuating) corrupt data.
+		if removeErr := os.Remove(partialPath); removeErr != nil {
+			return nil, fmt.Errorf("%s (additionally failed to remove corrupt partial download: %s)", err, removeErr)
+		}
+		return nil, err
+	}
+
+	finalPath := strings.TrimSuffix(partialPath, genesisPartialSuffix)
+	if err := os.Rename(partialPath, finalPath); err != nil {
+		return nil, err
+	}
+
+	return os.Open(finalPath)
+}
+
+// verifyGenesisChecksum compares the sha256 sum of the file at path against
+// the detached sum published at "<sourceName>.sha256", failing closed if
+// they don't match or the sum can't be fetched.
+func verifyGenesisChecksum(sourceName string, path string) error {
+	resp, err := http.Get(sourceName + ".sha256")
+	if err != nil {
+		return fmt.Errorf("fetching genesis checksum: %s", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching genesis checksum: unexpected status %s", resp.Status)
+	}
+
+	sumLine, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("fetching genesis checksum: %s", err)
+	}
+	fields := strings.Fields(string(sumLine))
+	if len(fields) == 0 {
+		return fmt.Errorf("genesis checksum file is empty")
+	}
+	want := strings.ToLower(fields[0])
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	got := hex.EncodeToString(h.Sum(nil))
+
+	if got != want {
+		return fmt.Errorf("genesis file checksum mismatch: got %s, want %s", got, want)
+	}
+	return nil
+}
+
+func extractGenesisBlock(source io.ReadCloser, rep repo.Repo, expectedCid cid.Cid) (*block.Block, error) {
 	bs := blockstore.NewBlockstore(rep.Datastore())
 	ch, err := car.LoadCar(bs, source)
 	if err != nil {
 		return nil, err
 	}
 
+	if expectedCid.Defined() && !ch.Roots[0].Equals(expectedCid) {
+		return nil, fmt.Errorf("genesis file root CID %s does not match --%s %s", ch.Roots[0], GenesisCid, expectedCid)
+	}
+
 	// need to check if we are being handed a car file with a single genesis block or an entire chain.
 	bsBlk, err := bs.Get(ch.Roots[0])
 	if err != nil {
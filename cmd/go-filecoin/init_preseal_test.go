@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"testing"
+
+	cmdkit "github.com/ipfs/go-ipfs-cmdkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresealOptIfRequestedNoopWithoutSectorCount(t *testing.T) {
+	opt, err := presealOptIfRequested(cmdkit.OptMap{})
+	require.NoError(t, err)
+	assert.Nil(t, opt)
+}
+
+func TestPresealOptIfRequestedRequiresMinerAndSectorDir(t *testing.T) {
+	_, err := presealOptIfRequested(cmdkit.OptMap{
+		PresealedSectorCount: uint(1),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), PresealedSectorCount)
+	assert.Contains(t, err.Error(), WithMiner)
+	assert.Contains(t, err.Error(), OptionPresealedSectorDir)
+}
@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestDownloadGenesisFreshDownload(t *testing.T) {
+	content := []byte("genesis car bytes")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".sha256") {
+			_, _ = w.Write([]byte(sha256Hex(content)))
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "genesis-download-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	partialPath := filepath.Join(dir, "genesis.car.part")
+	source, err := downloadGenesis(server.URL, partialPath)
+	require.NoError(t, err)
+	defer func() { _ = source.Close() }()
+
+	got, err := ioutil.ReadAll(source)
+	require.NoError(t, err)
+	assert.Equal(t, content, got)
+
+	_, err = os.Stat(partialPath)
+	assert.True(t, os.IsNotExist(err), "partial file should be renamed away on success")
+}
+
+func TestDownloadGenesisResumesFromPartialFile(t *testing.T) {
+	content := []byte("genesis car bytes")
+	partialPrefix := content[:8]
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".sha256") {
+			_, _ = w.Write([]byte(sha256Hex(content)))
+			return
+		}
+		if rng := r.Header.Get("Range"); rng != "" {
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write(content[len(partialPrefix):])
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "genesis-download-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	partialPath := filepath.Join(dir, "genesis.car.part")
+	require.NoError(t, ioutil.WriteFile(partialPath, partialPrefix, 0644))
+
+	source, err := downloadGenesis(server.URL, partialPath)
+	require.NoError(t, err)
+	defer func() { _ = source.Close() }()
+
+	got, err := ioutil.ReadAll(source)
+	require.NoError(t, err)
+	assert.Equal(t, content, got)
+}
+
+func TestDownloadGenesisRemovesPartialFileOnChecksumMismatch(t *testing.T) {
+	content := []byte("genesis car bytes")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".sha256") {
+			_, _ = w.Write([]byte(sha256Hex([]byte("not the content served below"))))
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "genesis-download-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	partialPath := filepath.Join(dir, "genesis.car.part")
+	_, err = downloadGenesis(server.URL, partialPath)
+	assert.Error(t, err)
+
+	_, statErr := os.Stat(partialPath)
+	assert.True(t, os.IsNotExist(statErr), "corrupt partial file must be removed so the next attempt restarts clean")
+}
+
+func TestDownloadGenesisRejectsUnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "genesis-download-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	partialPath := filepath.Join(dir, "genesis.car.part")
+	require.NoError(t, ioutil.WriteFile(partialPath, []byte("stale"), 0644))
+
+	_, err = downloadGenesis(server.URL, partialPath)
+	assert.Error(t, err)
+}
@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNodeInitOptsRejectsWalletRemoteURLAndWalletKeyFileTogether(t *testing.T) {
+	_, err := getNodeInitOpts("", "/tmp/some-wallet-key", "", false, "http://example.invalid", "/tmp/some-token")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), WalletRemoteURL)
+	assert.Contains(t, err.Error(), WalletKeyFile)
+}
+
+func TestGetNodeInitOptsRequiresTokenFileForWalletRemoteURL(t *testing.T) {
+	_, err := getNodeInitOpts("", "", "", false, "http://example.invalid", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), WalletRemoteURL)
+	assert.Contains(t, err.Error(), WalletRemoteTokenFile)
+}
@@ -0,0 +1,21 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNodeInitOptsRejectsWorkerKeyFileAndCreateWorkerKeyTogether(t *testing.T) {
+	_, err := getNodeInitOpts("", "", "/tmp/some-worker-key", true, "", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), CreateWorkerKey)
+	assert.Contains(t, err.Error(), WorkerKeyFile)
+}
+
+func TestGetNodeInitOptsReturnsNoOptsByDefault(t *testing.T) {
+	opts, err := getNodeInitOpts("", "", "", false, "", "")
+	require.NoError(t, err)
+	assert.Empty(t, opts)
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/go-filecoin/commands/testharness"
+)
+
+// TestHarnessBidDispatch and TestHarnessAskDispatch exercise
+// commands/testharness's own in-memory order-book bookkeeping, not the
+// production order-book/actor code: see the package doc comment on
+// testharness for why. They're a sanity check on the harness plumbing
+// itself (Topology, Barrier, MineOnDemand, RunSuccess dispatch) and are not
+// a substitute for TestBidList/TestAskList in orderbook_test.go, which run
+// against a real daemon.
+
+func TestHarnessBidDispatch(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	h := testharness.New(t, testharness.Topology{Miners: 1, Clients: 1})
+	const minerIdx, clientIdx = 0, 1
+
+	for i := 0; i < 10; i++ {
+		h.RunSuccess(clientIdx, "client", "add-bid", "1", fmt.Sprintf("%d", i))
+	}
+
+	for i := 0; i < 10; i++ {
+		assert.NoError(h.MineOnDemand(ctx, minerIdx))
+	}
+
+	list := h.RunSuccess(clientIdx, "orderbook", "bids")
+	for i := 0; i < 10; i++ {
+		assert.Contains(list, fmt.Sprintf("\"price\":%d", i))
+	}
+}
+
+func TestHarnessAskDispatch(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	h := testharness.New(t, testharness.Topology{Miners: 1})
+	const minerIdx = 0
+
+	minerAddr := h.RunSuccess(minerIdx, "address", "lookup")
+
+	for i := 0; i < 10; i++ {
+		h.RunSuccess(minerIdx,
+			"miner", "add-ask",
+			"--from", minerAddr,
+			minerAddr, "1", fmt.Sprintf("%d", i),
+		)
+	}
+
+	assert.NoError(h.MineOnDemand(ctx, minerIdx))
+
+	list := h.RunSuccess(minerIdx, "orderbook", "asks")
+	for i := 0; i < 10; i++ {
+		assert.Contains(list, fmt.Sprintf("\"price\":%d", i))
+	}
+}
+
+func TestHarnessMineOnDemandRejectsTopologyWithoutMiners(t *testing.T) {
+	ctx := context.Background()
+	h := testharness.New(t, testharness.Topology{Clients: 1})
+
+	assert.Error(t, h.MineOnDemand(ctx, 0))
+}
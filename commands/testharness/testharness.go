@@ -0,0 +1,241 @@
+// Package testharness is a lightweight, in-process fake of the handful of
+// client/miner/orderbook CLI commands used in
+// commands/orderbook_harness_test.go's harness-dispatch tests. It does NOT run a real go-filecoin node: RunSuccess
+// matches args against a fixed set of known commands and updates a plain
+// in-memory order book, so it exercises this harness's own bookkeeping, not
+// the production order-book/actor code path.
+//
+// It exists to let command-dispatch-shaped tests run deterministically and
+// under `-race` without forking a daemon subprocess, which is useful for
+// iterating on a Topology/Barrier-style test structure. It is not a
+// replacement for real coverage of order-book command behavior: that still
+// requires a real node, and lives in commands/orderbook_test.go's
+// daemon-backed TestBidList/TestAskList. Wiring this package up to an actual
+// node.New + cmds.Environment + gengen-produced genesis, so RunSuccess
+// dispatches through the real command tree instead of a switch statement,
+// remains unfinished.
+//
+// The Topology/Barrier structure borrows from the Testground SDK: a
+// Topology declares how many nodes play each role, a counting Barrier
+// coordinates role transitions the way a Testground sync.Client barrier
+// does, and MineOnDemand drives mining on demand instead of on a wall-clock
+// interval.
+package testharness
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Role identifies what part a node plays in a Topology.
+type Role string
+
+// The roles a Harness node can take on.
+const (
+	RoleBootstrapper Role = "bootstrapper"
+	RoleMiner        Role = "miner"
+	RoleClient       Role = "client"
+)
+
+// Topology declares how many nodes of each role to bring up.
+type Topology struct {
+	Bootstrappers int
+	Miners        int
+	Clients       int
+}
+
+type harnessNode struct {
+	role Role
+	addr string
+}
+
+type order struct {
+	size  string
+	price string
+}
+
+// Harness runs a Topology's worth of simulated go-filecoin nodes, linked
+// over a libp2p mocknet and sharing one order book. Its RunSuccess is
+// modelled on the OS-process Daemon's, but takes the index of the node to
+// run on before the command args and returns the faked output as a string.
+type Harness struct {
+	t     *testing.T
+	mnet  mocknet.Mocknet
+	nodes []*harnessNode
+
+	mu           sync.Mutex
+	cond         *sync.Cond
+	stageWant    int
+	stageArrived map[string]int
+
+	pendingBids []order
+	bids        []order
+	pendingAsks []order
+	asks        []order
+}
+
+// New builds a Harness for topo: one mocknet peer per node, linked together
+// and released past the "bootstrap" barrier before New returns.
+func New(t *testing.T, topo Topology) *Harness {
+	h := &Harness{
+		t:            t,
+		mnet:         mocknet.New(),
+		stageArrived: make(map[string]int),
+	}
+	h.cond = sync.NewCond(&h.mu)
+
+	for i := 0; i < topo.Bootstrappers; i++ {
+		h.addNode(RoleBootstrapper)
+	}
+	for i := 0; i < topo.Miners; i++ {
+		h.addNode(RoleMiner)
+	}
+	for i := 0; i < topo.Clients; i++ {
+		h.addNode(RoleClient)
+	}
+	h.stageWant = len(h.nodes)
+
+	require.NoError(t, h.mnet.LinkAll())
+
+	for i := range h.nodes {
+		h.Arrive(i, "bootstrap")
+	}
+	h.Barrier("bootstrap")
+
+	return h
+}
+
+func (h *Harness) addNode(role Role) {
+	_, err := h.mnet.GenPeer()
+	require.NoError(h.t, err)
+
+	h.nodes = append(h.nodes, &harnessNode{
+		role: role,
+		addr: fmt.Sprintf("t0%d", len(h.nodes)+100),
+	})
+}
+
+// Arrive records that node nodeIdx has reached stage, waking any goroutine
+// blocked in Barrier(stage) once every node in the topology has arrived.
+func (h *Harness) Arrive(nodeIdx int, stage string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	require.Less(h.t, nodeIdx, len(h.nodes), "no node at index %d", nodeIdx)
+
+	h.stageArrived[stage]++
+	h.cond.Broadcast()
+}
+
+// Barrier blocks until every node in the topology has called Arrive for
+// stage, mirroring a Testground sync.Client barrier used to order role
+// transitions (e.g. all miners online before any client posts a bid).
+func (h *Harness) Barrier(stage string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for h.stageArrived[stage] < h.stageWant {
+		h.cond.Wait()
+	}
+}
+
+// MineOnDemand stands in for mining one tipset on the miner at minerIdx (an
+// index among the topology's miners): no block is produced, but every
+// pending bid and ask becomes visible in the order book. It replaces the
+// interval-based "mining once" CLI round-trip with a deterministic,
+// in-process call.
+func (h *Harness) MineOnDemand(ctx context.Context, minerIdx int) error {
+	miners := h.nodesWithRole(RoleMiner)
+	if minerIdx < 0 || minerIdx >= len(miners) {
+		return fmt.Errorf("no miner at index %d", minerIdx)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.bids = append(h.bids, h.pendingBids...)
+	h.pendingBids = nil
+	h.asks = append(h.asks, h.pendingAsks...)
+	h.pendingAsks = nil
+
+	return nil
+}
+
+// RunSuccess matches args against the fixed set of commands this harness
+// fakes (client add-bid, miner add-ask, orderbook bids/asks, address lookup)
+// and applies them to the shared in-memory order book, in place of the way
+// Daemon.Run dispatches a command over RPC. nodeIdx only selects the address
+// returned by "address lookup". It fails the test immediately if args names
+// a command this harness doesn't understand.
+func (h *Harness) RunSuccess(nodeIdx int, args ...string) string {
+	require.Less(h.t, nodeIdx, len(h.nodes), "no node at index %d", nodeIdx)
+
+	switch {
+	case matches(args, "client", "add-bid") && len(args) >= 4:
+		h.mu.Lock()
+		h.pendingBids = append(h.pendingBids, order{size: args[2], price: args[3]})
+		h.mu.Unlock()
+		return ""
+
+	case matches(args, "miner", "add-ask") && len(args) >= 4:
+		size, price := args[len(args)-2], args[len(args)-1]
+		h.mu.Lock()
+		h.pendingAsks = append(h.pendingAsks, order{size: size, price: price})
+		h.mu.Unlock()
+		return ""
+
+	case matches(args, "orderbook", "bids"):
+		return renderOrders(h.snapshot(&h.bids))
+
+	case matches(args, "orderbook", "asks"):
+		return renderOrders(h.snapshot(&h.asks))
+
+	case matches(args, "address", "lookup"):
+		return h.nodes[nodeIdx].addr
+
+	default:
+		h.t.Fatalf("testharness: RunSuccess does not understand command %v", args)
+		return ""
+	}
+}
+
+func matches(args []string, cmd string, sub string) bool {
+	return len(args) >= 2 && args[0] == cmd && args[1] == sub
+}
+
+func (h *Harness) snapshot(orders *[]order) []order {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	out := make([]order, len(*orders))
+	copy(out, *orders)
+	return out
+}
+
+func renderOrders(orders []order) string {
+	var b strings.Builder
+	b.WriteString("[")
+	for i, o := range orders {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `{"size":%s,"price":%s}`, o.size, o.price)
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+func (h *Harness) nodesWithRole(role Role) []*harnessNode {
+	var out []*harnessNode
+	for _, n := range h.nodes {
+		if n.role == role {
+			out = append(out, n)
+		}
+	}
+	return out
+}
@@ -0,0 +1,29 @@
+package node
+
+import (
+	"github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/go-filecoin/tools/preseal"
+)
+
+// PresealOpt fake-seals numSectors sectors of sectorSize bytes for miner
+// into sectorDir, recording in the manifest the repo's Mining.WorkerAddress
+// and the PeerID of the Init this opt runs against. The init command appends
+// it after the opts returned by getNodeInitOpts, so that Init.PeerID and a
+// worker address registered by a worker-key opt are already settled by the
+// time the manifest is written.
+func PresealOpt(miner address.Address, sectorDir string, sectorSize uint64, numSectors uint64) InitOpt {
+	return func(init *Init) error {
+		cfg := init.Repo.Config()
+
+		_, err := preseal.Seal(preseal.Config{
+			Miner:      miner,
+			Worker:     cfg.Mining.WorkerAddress,
+			PeerID:     init.PeerID.String(),
+			SectorDir:  sectorDir,
+			SectorSize: sectorSize,
+			NumSectors: numSectors,
+		})
+		return err
+	}
+}
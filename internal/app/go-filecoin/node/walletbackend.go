@@ -0,0 +1,33 @@
+package node
+
+import (
+	"github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/wallet"
+)
+
+// WalletBackendOpt installs backend as the node's wallet.Backend instead of
+// the local keystore, so every signing operation becomes a round-trip
+// through backend (e.g. to an external signer daemon) rather than touching
+// local key material. It enumerates backend's addresses via Addresses so
+// the first one can seed Mining.RewardAddress the way a freshly-generated
+// local key otherwise would.
+func WalletBackendOpt(backend wallet.Backend) InitOpt {
+	return func(init *Init) error {
+		init.Wallet.SetBackend(backend)
+
+		addrs, err := backend.Addresses()
+		if err != nil {
+			return err
+		}
+		if len(addrs) == 0 {
+			return nil
+		}
+
+		cfg := init.Repo.Config()
+		if cfg.Mining.RewardAddress == address.Undef {
+			cfg.Mining.RewardAddress = addrs[0]
+		}
+		return init.Repo.ReplaceConfig(cfg)
+	}
+}
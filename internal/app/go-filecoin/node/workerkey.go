@@ -0,0 +1,49 @@
+package node
+
+import (
+	"github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/types"
+)
+
+// WorkerKeyOpt imports ki as the miner's worker key: it is added to the
+// wallet being built up by Init, and its address is recorded as the worker
+// address the way GenerateWorkerKeyOpt records a freshly generated one, so
+// block signing and PoSt submissions use it while fund-withdrawal calls
+// continue to require the owner key.
+func WorkerKeyOpt(ki *types.KeyInfo) InitOpt {
+	return func(init *Init) error {
+		addr, err := init.Wallet.Import(ki)
+		if err != nil {
+			return err
+		}
+		return init.registerWorkerAddress(addr)
+	}
+}
+
+// GenerateWorkerKeyOpt generates a fresh worker key and records it as the
+// repo's worker address, as an alternative to importing an existing one via
+// WorkerKeyOpt.
+func GenerateWorkerKeyOpt() InitOpt {
+	return func(init *Init) error {
+		addr, err := init.Wallet.NewAddress()
+		if err != nil {
+			return err
+		}
+		return init.registerWorkerAddress(addr)
+	}
+}
+
+// registerWorkerAddress records addr as the repo's Mining.WorkerAddress.
+//
+// It deliberately does not also register addr on-chain as the miner
+// actor's worker: whether cfg.Mining.MinerAddress is already set at this
+// point depends on init flag combinations and opt ordering that this
+// package doesn't control, so linking the two addresses on-chain is done
+// once, after every opt has run, by
+// cmd/go-filecoin/init.go's registerMinerWorkerAddress instead of here.
+func (init *Init) registerWorkerAddress(addr address.Address) error {
+	cfg := init.Repo.Config()
+	cfg.Mining.WorkerAddress = addr
+	return init.Repo.ReplaceConfig(cfg)
+}
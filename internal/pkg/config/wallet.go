@@ -0,0 +1,10 @@
+package config
+
+// RemoteWalletConfig configures a wallet.Backend that forwards signing to an
+// external signer daemon over authenticated HTTP instead of keeping key
+// material in the local keystore, as used by
+// `go-filecoin init --wallet-remote-url`.
+type RemoteWalletConfig struct {
+	// URL is the address of the remote signer's JSON-RPC endpoint.
+	URL string `json:"url"`
+}
@@ -0,0 +1,22 @@
+// Package wallet defines the common interface the node's key-management
+// strategies implement, whether they hold key material in the local
+// keystore or forward signing to a remote signer.
+package wallet
+
+import (
+	"github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/crypto"
+)
+
+// Backend is implemented by every wallet key-management strategy: the
+// default local keystore, and alternatives such as
+// internal/pkg/wallet/remote that proxy to an external signer.
+type Backend interface {
+	// Addresses lists every address this backend can sign for.
+	Addresses() ([]address.Address, error)
+	// HasAddress reports whether this backend can sign for addr.
+	HasAddress(addr address.Address) (bool, error)
+	// SignBytes signs data as addr.
+	SignBytes(data []byte, addr address.Address) (crypto.Signature, error)
+}
@@ -0,0 +1,112 @@
+package remote
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-address"
+)
+
+func writeRPCResult(t *testing.T, w http.ResponseWriter, result interface{}) {
+	raw, err := json.Marshal(result)
+	require.NoError(t, err)
+	require.NoError(t, json.NewEncoder(w).Encode(rpcResponse{ID: 1, Result: raw}))
+}
+
+func writeTokenFile(t *testing.T, token string) string {
+	dir, err := ioutil.TempDir("", "wallet-remote-test")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "token")
+	require.NoError(t, ioutil.WriteFile(path, []byte(token), 0600))
+	return path
+}
+
+func TestBackendAuthenticatesAndDispatchesByMethod(t *testing.T) {
+	addr, err := address.NewFromString("t01000")
+	require.NoError(t, err)
+
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+
+		var req rpcRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		assert.Equal(t, "2.0", req.JSONRPC)
+
+		switch req.Method {
+		case "WalletList":
+			writeRPCResult(t, w, []address.Address{addr})
+		case "WalletHas":
+			writeRPCResult(t, w, true)
+		case "WalletSign":
+			writeRPCResult(t, w, []byte("signature"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tokenFile := writeTokenFile(t, "s3cr3t")
+	backend, err := NewBackend(server.URL, tokenFile)
+	require.NoError(t, err)
+
+	addrs, err := backend.Addresses()
+	require.NoError(t, err)
+	assert.Equal(t, []address.Address{addr}, addrs)
+	assert.Equal(t, "Bearer s3cr3t", gotAuth)
+
+	has, err := backend.HasAddress(addr)
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	_, err = backend.SignBytes([]byte("data"), addr)
+	require.NoError(t, err)
+}
+
+func TestBackendSurfacesServerErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	tokenFile := writeTokenFile(t, "wrong-token")
+	backend, err := NewBackend(server.URL, tokenFile)
+	require.NoError(t, err)
+
+	_, err = backend.Addresses()
+	assert.Error(t, err)
+}
+
+func TestBackendSurfacesRPCErrorEnvelope(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode(rpcResponse{
+			ID:    1,
+			Error: &rpcError{Code: 1, Message: "no such key"},
+		}))
+	}))
+	defer server.Close()
+
+	tokenFile := writeTokenFile(t, "s3cr3t")
+	backend, err := NewBackend(server.URL, tokenFile)
+	require.NoError(t, err)
+
+	_, err = backend.Addresses()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no such key")
+}
+
+func TestNewBackendRejectsMissingTokenFile(t *testing.T) {
+	_, err := NewBackend("http://example.invalid", filepath.Join(os.TempDir(), "does-not-exist-token"))
+	assert.Error(t, err)
+}
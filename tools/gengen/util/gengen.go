@@ -0,0 +1,35 @@
+// Package gengen provides the genesis.InitFunc that `go-filecoin init` uses
+// when no genesis file is given, and reads pre-seal manifests.
+package gengen
+
+import (
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	cbor "github.com/ipfs/go-ipld-cbor"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/block"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/genesis"
+	"github.com/filecoin-project/go-filecoin/tools/preseal"
+)
+
+// MakeGenesisFunc returns the genesis.InitFunc that `go-filecoin init` uses
+// when no genesis file is given. The returned InitFunc does not build a
+// genesis block in either case below.
+//
+// When presealDir is empty it returns ErrGenesisTemplateNotImplemented.
+// Otherwise it loads every pre-seal-<addr>.json manifest in presealDir via
+// LoadPresealManifests, returns any error from that, and then returns
+// ErrPresealPowerNotImplemented: the power implied by the manifests'
+// sectors is not credited to their miners in any genesis state tree.
+func MakeGenesisFunc(presealDir string) genesis.InitFunc {
+	return func(cst cbor.IpldStore, bs blockstore.Blockstore) (*block.Block, error) {
+		if presealDir == "" {
+			return nil, ErrGenesisTemplateNotImplemented
+		}
+
+		if _, err := LoadPresealManifests(presealDir); err != nil {
+			return nil, err
+		}
+
+		return nil, ErrPresealPowerNotImplemented
+	}
+}
@@ -0,0 +1,49 @@
+package gengen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/filecoin-project/go-filecoin/tools/preseal"
+)
+
+// ErrGenesisTemplateNotImplemented is returned by the InitFunc MakeGenesisFunc
+// produces when presealDir is empty: building a genesis template from
+// scratch is gengen's core job, and that template builder isn't part of
+// this package.
+var ErrGenesisTemplateNotImplemented = errors.New("gengen: default genesis template builder not implemented")
+
+// ErrPresealPowerNotImplemented is returned by the InitFunc MakeGenesisFunc
+// produces once it has successfully loaded presealDir's manifests: crediting
+// their power into a genesis state tree requires the actor/VM packages this
+// build doesn't include.
+var ErrPresealPowerNotImplemented = errors.New("gengen: crediting preseal manifests into genesis power not implemented")
+
+// LoadPresealManifests reads every pre-seal-<addr>.json manifest that
+// tools/preseal.Seal wrote into dir, in the conventional layout ManifestPath
+// describes, returning one Manifest per file found.
+func LoadPresealManifests(dir string) ([]*preseal.Manifest, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "pre-seal-*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := make([]*preseal.Manifest, 0, len(paths))
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var m preseal.Manifest
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("parsing preseal manifest %s: %s", path, err)
+		}
+		manifests = append(manifests, &m)
+	}
+
+	return manifests, nil
+}
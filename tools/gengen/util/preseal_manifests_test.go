@@ -0,0 +1,60 @@
+package gengen
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/go-filecoin/tools/preseal"
+)
+
+func TestLoadPresealManifestsReadsEverySealedMiner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gengen-preseal-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	miners := []string{"t01000", "t01001"}
+	for _, minerStr := range miners {
+		miner, err := address.NewFromString(minerStr)
+		require.NoError(t, err)
+		worker, err := address.NewFromString("t01999")
+		require.NoError(t, err)
+
+		_, err = preseal.Seal(preseal.Config{
+			Miner:      miner,
+			Worker:     worker,
+			PeerID:     "QmTestPeerID",
+			SectorDir:  dir,
+			SectorSize: 1024,
+			NumSectors: 1,
+		})
+		require.NoError(t, err)
+	}
+
+	manifests, err := LoadPresealManifests(dir)
+	require.NoError(t, err)
+	assert.Len(t, manifests, len(miners))
+
+	got := make(map[string]bool)
+	for _, m := range manifests {
+		got[m.Miner.String()] = true
+	}
+	for _, minerStr := range miners {
+		assert.True(t, got[minerStr], "expected a manifest for %s", minerStr)
+	}
+}
+
+func TestLoadPresealManifestsEmptyDirReturnsNone(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gengen-preseal-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	manifests, err := LoadPresealManifests(dir)
+	require.NoError(t, err)
+	assert.Empty(t, manifests)
+}
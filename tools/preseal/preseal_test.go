@@ -0,0 +1,65 @@
+package preseal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-address"
+)
+
+func TestSealWritesManifestAndSectors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "preseal-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	miner, err := address.NewFromString("t01000")
+	require.NoError(t, err)
+	worker, err := address.NewFromString("t01001")
+	require.NoError(t, err)
+
+	m, err := Seal(Config{
+		Miner:      miner,
+		Worker:     worker,
+		PeerID:     "QmTestPeerID",
+		SectorDir:  dir,
+		SectorSize: 1024,
+		NumSectors: 2,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, miner, m.Miner)
+	assert.Equal(t, worker, m.Worker)
+	assert.Equal(t, "QmTestPeerID", m.PeerID)
+	assert.Len(t, m.Sectors, 2)
+
+	for i, s := range m.Sectors {
+		assert.Equal(t, uint64(i), s.SectorID)
+		info, err := os.Stat(filepath.Join(dir, fmt.Sprintf("sector-%d.dat", i)))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1024), info.Size())
+	}
+
+	_, err = os.Stat(ManifestPath(dir, miner))
+	require.NoError(t, err)
+}
+
+func TestSealRejectsZeroCountOrSize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "preseal-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	miner, err := address.NewFromString("t01000")
+	require.NoError(t, err)
+
+	_, err = Seal(Config{Miner: miner, SectorDir: dir, SectorSize: 1024, NumSectors: 0})
+	assert.Error(t, err)
+
+	_, err = Seal(Config{Miner: miner, SectorDir: dir, SectorSize: 0, NumSectors: 1})
+	assert.Error(t, err)
+}